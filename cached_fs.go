@@ -0,0 +1,207 @@
+package main
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/logging"
+)
+
+// cachedFS wraps an on-disk directory, caching each file's contents in
+// memory the first time it's read and dropping a file's cache entry as
+// soon as fsnotify reports that it changed. This avoids re-reading the
+// templates directory mounted into the Function's container on every
+// RunFunction call, while still picking up edits without a restart.
+// Directories are always delegated straight to base, so fs.WalkDir (used
+// by loadNamedTemplatesFromFS) still sees a live directory listing.
+type cachedFS struct {
+	// dir is the directory base is rooted at, used to turn an fsnotify
+	// event's absolute path back into the "/"-separated, dir-relative
+	// path files is keyed by (the same path fs.WalkDir - and so
+	// loadNamedTemplatesFromFS - reports).
+	dir  string
+	base fs.FS
+	log  logging.Logger
+
+	mu    sync.RWMutex
+	files map[string][]byte
+
+	watcher *fsnotify.Watcher
+}
+
+// newCachedFS watches dir, and every subdirectory beneath it, for
+// changes and returns an fs.FS backed by it. fsnotify watches aren't
+// recursive, so templates laid out across subdirectories (as
+// loadNamedTemplatesFromFS supports) need a watch on each one. Callers
+// should Close the returned cachedFS once it's no longer needed to stop
+// the underlying watcher goroutine.
+func newCachedFS(dir string, log logging.Logger) (*cachedFS, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create filesystem watcher")
+	}
+
+	if err := addDirsRecursive(watcher, dir); err != nil {
+		_ = watcher.Close()
+		return nil, errors.Wrapf(err, "cannot watch %q", dir)
+	}
+
+	c := &cachedFS{
+		dir:     dir,
+		base:    os.DirFS(dir),
+		log:     log,
+		files:   map[string][]byte{},
+		watcher: watcher,
+	}
+
+	go c.watch()
+
+	return c, nil
+}
+
+// addDirsRecursive adds a watch on dir and every directory beneath it.
+func addDirsRecursive(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return watcher.Add(path)
+	})
+}
+
+// Open implements fs.FS, serving name from cache when possible.
+func (c *cachedFS) Open(name string) (fs.File, error) {
+	c.mu.RLock()
+	b, ok := c.files[name]
+	c.mu.RUnlock()
+	if ok {
+		return &memFile{name: name, data: b}, nil
+	}
+
+	f, err := c.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // nothing useful to do with a close error after a successful read.
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		// Directory listings aren't cached, since loadNamedTemplatesFromFS
+		// needs to see new or removed files without a restart.
+		return c.base.Open(name)
+	}
+
+	b, err = io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.files[name] = b
+	c.mu.Unlock()
+
+	return &memFile{name: name, data: b}, nil
+}
+
+// watch drops a file's cache entry whenever fsnotify reports that it
+// changed, so the next Open re-reads it from disk, and starts watching
+// any subdirectory created after newCachedFS ran (fsnotify watches don't
+// propagate to new subdirectories on their own).
+func (c *cachedFS) watch() {
+	for {
+		select {
+		case event, ok := <-c.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addDirsRecursive(c.watcher, event.Name); err != nil {
+						c.log.Info("cannot watch new directory", "path", event.Name, "error", err)
+					}
+				}
+			}
+
+			if name, ok := c.relPath(event.Name); ok {
+				c.invalidate(name)
+			}
+		case err, ok := <-c.watcher.Errors:
+			if !ok {
+				return
+			}
+			c.log.Info("filesystem watcher error", "error", err)
+		}
+	}
+}
+
+// relPath turns path, an absolute path fsnotify reported, into the
+// "/"-separated path relative to c.dir that files is keyed by.
+func (c *cachedFS) relPath(path string) (string, bool) {
+	rel, err := filepath.Rel(c.dir, path)
+	if err != nil {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
+
+// invalidate drops name's cache entry, if any.
+func (c *cachedFS) invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.files, name)
+}
+
+// Close stops watching the directory.
+func (c *cachedFS) Close() error {
+	return c.watcher.Close()
+}
+
+// memFile is an in-memory fs.File backing a cachedFS cache hit.
+type memFile struct {
+	name string
+	data []byte
+	off  int
+}
+
+func (m *memFile) Stat() (fs.FileInfo, error) {
+	return memFileInfo{name: filepath.Base(m.name), size: int64(len(m.data))}, nil
+}
+
+func (m *memFile) Read(b []byte) (int, error) {
+	if m.off >= len(m.data) {
+		return 0, io.EOF
+	}
+	n := copy(b, m.data[m.off:])
+	m.off += n
+	return n, nil
+}
+
+func (m *memFile) Close() error { return nil }
+
+type memFileInfo struct {
+	name string
+	size int64
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return fi.size }
+func (fi memFileInfo) Mode() fs.FileMode  { return 0 }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return false }
+func (fi memFileInfo) Sys() any           { return nil }
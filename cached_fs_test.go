@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crossplane/function-sdk-go/logging"
+)
+
+func TestCachedFSCachesAndInvalidates(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "composite.yaml"), []byte("v1"), 0o600); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+
+	log := logging.NewNopLogger()
+
+	c, err := newCachedFS(dir, log)
+	if err != nil {
+		t.Fatalf("newCachedFS: unexpected error: %v", err)
+	}
+	defer c.Close() //nolint:errcheck // test cleanup.
+
+	b, err := readAll(c, "composite.yaml")
+	if err != nil {
+		t.Fatalf("readAll: unexpected error: %v", err)
+	}
+	if string(b) != "v1" {
+		t.Fatalf("readAll: got %q, want %q", b, "v1")
+	}
+
+	// Stop the watcher, then change the file on disk without invalidating
+	// the cache: the cached content should still be served.
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "composite.yaml"), []byte("v2"), 0o600); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+	if b, err := readAll(c, "composite.yaml"); err != nil || string(b) != "v1" {
+		t.Fatalf("readAll before invalidate: got (%q, %v), want (\"v1\", nil)", b, err)
+	}
+
+	// Simulate fsnotify reporting the change: the next read should pick
+	// up the new content.
+	c.invalidate("composite.yaml")
+	if b, err := readAll(c, "composite.yaml"); err != nil || string(b) != "v2" {
+		t.Fatalf("readAll after invalidate: got (%q, %v), want (\"v2\", nil)", b, err)
+	}
+}
+
+// TestCachedFSInvalidatesInSubdirectory proves a real fsnotify event for
+// a file nested in a subdirectory actually invalidates that file's cache
+// entry - a subdirectory needs its own watch, since fsnotify watches
+// aren't recursive, and the cache key needs to be the path relative to
+// dir (e.g. "helpers/labels.yaml"), not just the event's basename.
+func TestCachedFSInvalidatesInSubdirectory(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "helpers")
+	if err := os.Mkdir(sub, 0o700); err != nil {
+		t.Fatalf("Mkdir: unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "labels.yaml"), []byte("v1"), 0o600); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+
+	log := logging.NewNopLogger()
+
+	c, err := newCachedFS(dir, log)
+	if err != nil {
+		t.Fatalf("newCachedFS: unexpected error: %v", err)
+	}
+	defer c.Close() //nolint:errcheck // test cleanup.
+
+	name := filepath.ToSlash(filepath.Join("helpers", "labels.yaml"))
+
+	if b, err := readAll(c, name); err != nil || string(b) != "v1" {
+		t.Fatalf("readAll: got (%q, %v), want (\"v1\", nil)", b, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(sub, "labels.yaml"), []byte("v2"), 0o600); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		b, err := readAll(c, name)
+		if err == nil && string(b) == "v2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("readAll after on-disk change: got (%q, %v), want eventual (\"v2\", nil)", b, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func readAll(c *cachedFS, name string) ([]byte, error) {
+	f, err := c.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // test cleanup.
+
+	buf := make([]byte, 0, 16)
+	tmp := make([]byte, 16)
+	for {
+		n, err := f.Read(tmp)
+		buf = append(buf, tmp[:n]...)
+		if err != nil {
+			break
+		}
+	}
+	return buf, nil
+}
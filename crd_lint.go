@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// crd is the minimal shape of a CustomResourceDefinition LintCmd reads -
+// enough to match a rendered resource's apiVersion/kind and check that
+// the fields each of its top-level properties (e.g. spec) declares
+// required are present. It deliberately doesn't
+// model the full OpenAPI schema.
+type crd struct {
+	Spec crdSpec `json:"spec"`
+}
+
+type crdSpec struct {
+	Group    string       `json:"group"`
+	Names    crdNames     `json:"names"`
+	Versions []crdVersion `json:"versions"`
+}
+
+type crdNames struct {
+	Kind string `json:"kind"`
+}
+
+type crdVersion struct {
+	Name   string    `json:"name"`
+	Schema crdSchema `json:"schema"`
+}
+
+type crdSchema struct {
+	OpenAPIV3Schema crdOpenAPISchema `json:"openAPIV3Schema"`
+}
+
+type crdOpenAPISchema struct {
+	Properties map[string]crdProperty `json:"properties"`
+}
+
+type crdProperty struct {
+	Required []string `json:"required"`
+}
+
+// loadCRDs reads every *.yaml/*.yml file in dir as a
+// CustomResourceDefinition.
+func loadCRDs(dir string) ([]crd, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var crds []crd
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var c crd
+		if err := sigsyaml.Unmarshal(b, &c); err != nil {
+			return nil, errors.Wrapf(err, "cannot parse CRD %q", e.Name())
+		}
+		crds = append(crds, c)
+	}
+
+	return crds, nil
+}
+
+// validateAgainstCRDs checks that, for each top-level property (e.g.
+// spec) of whichever crds entry matches obj's apiVersion and kind, the
+// fields that property declares required are present in obj, returning a
+// human-readable violation for each one that isn't. This is a shallow
+// check: it validates presence, not deeper nested schema or field types,
+// and the schema's own top-level required list isn't read.
+func validateAgainstCRDs(obj map[string]any, crds []crd) []string {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	if apiVersion == "" || kind == "" {
+		return nil
+	}
+
+	group, version, ok := strings.Cut(apiVersion, "/")
+	if !ok {
+		// Core/v1 resources (no group) aren't CRDs; nothing to validate.
+		return nil
+	}
+
+	var violations []string
+	for _, c := range crds {
+		if c.Spec.Group != group || c.Spec.Names.Kind != kind {
+			continue
+		}
+
+		for _, v := range c.Spec.Versions {
+			if v.Name != version {
+				continue
+			}
+
+			for prop, schema := range v.Schema.OpenAPIV3Schema.Properties {
+				sub, _ := obj[prop].(map[string]any)
+				for _, req := range schema.Required {
+					if _, ok := sub[req]; !ok {
+						violations = append(violations, fmt.Sprintf("%s.%s: missing required field %q", kind, prop, req))
+					}
+				}
+			}
+		}
+	}
+
+	return violations
+}
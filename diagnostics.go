@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// contextLines is how many lines of source are shown on either side of
+// the offending line in a YamlErrorContext snippet.
+const contextLines = 3
+
+// YamlErrorContext carries enough detail about a single failing document
+// to render a useful diagnostic: which document it was, where the error
+// is in the rendered manifest stream, and a source snippet around it.
+type YamlErrorContext struct {
+	// DocIndex is the zero-based index of the "---"-delimited document
+	// the error occurred in.
+	DocIndex int
+	// AbsLine is the 1-based line number of the error: within the full
+	// rendered manifest stream for a YAML decode error, or within
+	// TemplateName's source for a template execution error.
+	AbsLine int
+	// Column is the 1-based column the error was reported at, or 0 if
+	// the underlying parser didn't expose one.
+	Column int
+	// TemplateName is the name of the template AbsLine and Snippet are
+	// relative to, set only for template execution errors. It's empty
+	// for YAML decode errors, which are relative to the rendered
+	// manifest stream instead.
+	TemplateName string
+	// Message is the underlying parser or template engine error message.
+	Message string
+	// Snippet is up to contextLines lines of source on either side of
+	// AbsLine, each prefixed with its line number, with a "^" caret under
+	// Column when it's known.
+	Snippet string
+}
+
+// moveToNextDoc returns the line number, starting from startLine, of the
+// next "---" document divider in lines, or startLine if there isn't one.
+func moveToNextDoc(lines []string, startLine int) int {
+	for i := startLine; i <= len(lines); i++ {
+		if strings.TrimSpace(lines[i-1]) == "---" && i > startLine {
+			return i
+		}
+	}
+	return startLine
+}
+
+// yamlLineErrRE matches the line k8s.io/apimachinery's YAML-to-JSON
+// conversion reports a decode error against, e.g.
+// "error converting YAML to JSON: yaml: line 2: mapping values are not
+// allowed in this context".
+var yamlLineErrRE = regexp.MustCompile(`yaml: line (\d+):\s*(.*)$`)
+
+// newYamlErrorContext builds a YamlErrorContext for a manifest decode
+// error in the document at docIndex, which starts on startLine of lines
+// (the full rendered manifest stream split on "\n").
+func newYamlErrorContext(err error, docIndex, startLine int, lines []string) YamlErrorContext {
+	m := yamlLineErrRE.FindStringSubmatch(err.Error())
+	if m == nil {
+		return YamlErrorContext{
+			DocIndex: docIndex,
+			AbsLine:  startLine,
+			Message:  err.Error(),
+			Snippet:  snippet(lines, startLine, 0),
+		}
+	}
+
+	relLine, _ := strconv.Atoi(m[1])
+	absLine := startLine + relLine - 1
+
+	return YamlErrorContext{
+		DocIndex: docIndex,
+		AbsLine:  absLine,
+		Message:  m[2],
+		Snippet:  snippet(lines, absLine, 0),
+	}
+}
+
+// templateExecErrRE matches the "template: <name>:<line>[:<col>]:
+// <message>" format text/template reports execution errors in.
+var templateExecErrRE = regexp.MustCompile(`^template: ([^:]*):(\d+)(?::(\d+))?: executing ".*?" at .*?: (.*)$`)
+
+// newTemplateErrorContext builds a YamlErrorContext for a template
+// execution error. text/template reports the line and column of the
+// failing action as a position in the named template's own source, not
+// in the rendered output - a range or if above the action shifts every
+// subsequent rendered line out of step with it - so the snippet is built
+// from sources[name], not from whatever's been rendered so far.
+func newTemplateErrorContext(err error, sources map[string]string) (YamlErrorContext, bool) {
+	m := templateExecErrRE.FindStringSubmatch(err.Error())
+	if m == nil {
+		return YamlErrorContext{}, false
+	}
+
+	name := m[1]
+	line, _ := strconv.Atoi(m[2])
+	var col int
+	if m[3] != "" {
+		col, _ = strconv.Atoi(m[3])
+	}
+
+	srcLines := strings.Split(sources[name], "\n")
+	if line > len(srcLines) {
+		line = len(srcLines)
+	}
+
+	return YamlErrorContext{
+		TemplateName: name,
+		AbsLine:      line,
+		Column:       col,
+		Message:      m[4],
+		Snippet:      snippet(srcLines, line, col),
+	}, true
+}
+
+// snippet renders up to contextLines lines of source on either side of
+// absLine, each prefixed with its 1-based line number, with a "^" caret
+// under column when it's known (column == 0 means unknown).
+func snippet(lines []string, absLine, column int) string {
+	lo := absLine - contextLines
+	if lo < 1 {
+		lo = 1
+	}
+	hi := absLine + contextLines
+	if hi > len(lines) {
+		hi = len(lines)
+	}
+
+	var b strings.Builder
+	for i := lo; i <= hi; i++ {
+		if i < 1 || i > len(lines) {
+			continue
+		}
+		fmt.Fprintf(&b, "%4d | %s\n", i, lines[i-1])
+		if i == absLine && column > 0 {
+			fmt.Fprintf(&b, "     | %s^\n", strings.Repeat(" ", column-1))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// formatYamlErrorContexts renders ctxs as a single message aggregating
+// every failing document, suitable for passing to response.Fatal.
+func formatYamlErrorContexts(ctxs []YamlErrorContext) string {
+	parts := make([]string, len(ctxs))
+	for i, c := range ctxs {
+		if c.TemplateName != "" {
+			parts[i] = fmt.Sprintf("template %q, line %d: %s\n%s", c.TemplateName, c.AbsLine, c.Message, c.Snippet)
+			continue
+		}
+		parts[i] = fmt.Sprintf("document %d, line %d: %s\n%s", c.DocIndex, c.AbsLine, c.Message, c.Snippet)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewYamlErrorContext(t *testing.T) {
+	lines := strings.Split("a: 1\n---\nb: [1, 2\n---\nc: 3\n", "\n")
+
+	err := errorString("error converting YAML to JSON: yaml: line 1: did not find expected ',' or ']'")
+
+	got := newYamlErrorContext(err, 1, 3, lines)
+
+	if got.DocIndex != 1 {
+		t.Errorf("DocIndex: got %d, want 1", got.DocIndex)
+	}
+	if got.AbsLine != 3 {
+		t.Errorf("AbsLine: got %d, want 3", got.AbsLine)
+	}
+	if !strings.Contains(got.Message, "did not find expected") {
+		t.Errorf("Message: got %q, missing expected text", got.Message)
+	}
+	if !strings.Contains(got.Snippet, "b: [1, 2") {
+		t.Errorf("Snippet: got %q, missing offending line", got.Snippet)
+	}
+}
+
+func TestFormatYamlErrorContextsMultiDoc(t *testing.T) {
+	lines := strings.Split("a: 1\n---\nb: [1, 2\n---\nc: }\n", "\n")
+
+	ctxs := []YamlErrorContext{
+		newYamlErrorContext(errorString("error converting YAML to JSON: yaml: line 1: did not find expected ',' or ']'"), 1, 3, lines),
+		newYamlErrorContext(errorString("error converting YAML to JSON: yaml: line 1: did not find expected node content"), 2, 5, lines),
+	}
+
+	got := formatYamlErrorContexts(ctxs)
+
+	for _, want := range []string{"document 1, line 3", "document 2, line 5"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatYamlErrorContexts: missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestNewTemplateErrorContext(t *testing.T) {
+	sources := map[string]string{
+		"composition-template": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .missing.field }}\n",
+	}
+
+	err := errorString(`template: composition-template:4:10: executing "composition-template" at <.missing.field>: map has no entry for key "missing"`)
+
+	got, ok := newTemplateErrorContext(err, sources)
+	if !ok {
+		t.Fatal("newTemplateErrorContext: expected ok, got false")
+	}
+	if got.TemplateName != "composition-template" {
+		t.Errorf("TemplateName: got %q, want %q", got.TemplateName, "composition-template")
+	}
+	if got.AbsLine != 4 {
+		t.Errorf("AbsLine: got %d, want 4", got.AbsLine)
+	}
+	if got.Column != 10 {
+		t.Errorf("Column: got %d, want 10", got.Column)
+	}
+	if !strings.Contains(got.Snippet, "^") {
+		t.Errorf("Snippet: missing caret: %q", got.Snippet)
+	}
+	if !strings.Contains(got.Snippet, "{{ .missing.field }}") {
+		t.Errorf("Snippet: got %q, missing offending template line", got.Snippet)
+	}
+}
+
+// TestNewTemplateErrorContextControlFlowAboveError proves the snippet is
+// taken from the named template's own source, not from the rendered
+// output - a range above the failing action shifts every subsequent
+// rendered line out of step with the template source that produced it,
+// so using the rendered buffer would point at the wrong line entirely.
+func TestNewTemplateErrorContextControlFlowAboveError(t *testing.T) {
+	sources := map[string]string{
+		"composition-template": "{{ range .items }}\nitem: {{ . }}\n{{ end }}\nname: {{ .missing.field }}\n",
+	}
+
+	// Rendering "name: {{ .missing.field }}" fails on line 4 of the
+	// template source, but - because the range above it expanded to
+	// more lines than it took in the source - on a much later line of
+	// the rendered output. newTemplateErrorContext must report line 4,
+	// the template source's line, not whatever line that is in the
+	// rendered buffer.
+	err := errorString(`template: composition-template:4:7: executing "composition-template" at <.missing.field>: map has no entry for key "missing"`)
+
+	got, ok := newTemplateErrorContext(err, sources)
+	if !ok {
+		t.Fatal("newTemplateErrorContext: expected ok, got false")
+	}
+	if got.AbsLine != 4 {
+		t.Errorf("AbsLine: got %d, want 4", got.AbsLine)
+	}
+	if !strings.Contains(got.Snippet, "name: {{ .missing.field }}") {
+		t.Errorf("Snippet: got %q, want it to contain the template source's offending line", got.Snippet)
+	}
+}
+
+func TestNewTemplateErrorContextNoMatch(t *testing.T) {
+	if _, ok := newTemplateErrorContext(errorString("some unrelated error"), nil); ok {
+		t.Error("newTemplateErrorContext: expected ok=false for an unrelated error")
+	}
+}
+
+type errorString string
+
+func (e errorString) Error() string { return string(e) }
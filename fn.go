@@ -3,7 +3,6 @@ package main
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io/fs"
 	"os"
 	"strings"
@@ -36,13 +35,11 @@ type Function struct {
 
 	log  logging.Logger
 	fsys fs.FS
-}
 
-type YamlErrorContext struct {
-	RelLine int
-	AbsLine int
-	Message string
-	Context string
+	// tmplCache caches parsed template trees across calls, keyed by a
+	// hash of their sources, delimiters and options. nil disables
+	// caching, which the --no-cache flag does for debugging.
+	tmplCache *templateCache
 }
 
 const (
@@ -72,21 +69,12 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 
 	f.log.Debug("template", "template", tg.GetTemplates())
 
-	tmpl, err := GetNewTemplateWithFunctionMaps(in.Delims).Parse(tg.GetTemplates())
+	tmpl, err := f.getTemplate(tg, in)
 	if err != nil {
-		response.Fatal(rsp, errors.Wrap(err, "invalid function input: cannot parse the provided templates"))
+		response.Fatal(rsp, err)
 		return rsp, nil
 	}
 
-	if in.Options != nil {
-		f.log.Debug("setting template options", "options", *in.Options)
-		err = safeApplyTemplateOptions(tmpl, *in.Options)
-		if err != nil {
-			response.Fatal(rsp, errors.Wrap(err, "cannot apply template options"))
-			return rsp, nil
-		}
-	}
-
 	reqMap, err := convertToMap(req)
 	if err != nil {
 		response.Fatal(rsp, errors.Wrap(err, "cannot convert request to map"))
@@ -97,19 +85,54 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1.RunFunctionRequest)
 
 	buf := &bytes.Buffer{}
 
-	if err := tmpl.Execute(buf, reqMap); err != nil {
+	if in.Entrypoint != nil {
+		err = tmpl.ExecuteTemplate(buf, *in.Entrypoint, reqMap)
+	} else {
+		err = tmpl.Execute(buf, reqMap)
+	}
+	if err != nil {
+		if tc, ok := newTemplateErrorContext(err, templateSources(tg)); ok {
+			response.Fatal(rsp, errors.Errorf("cannot execute template:\n%s", formatYamlErrorContexts([]YamlErrorContext{tc})))
+			return rsp, nil
+		}
 		response.Fatal(rsp, errors.Wrap(err, "cannot execute template"))
 		return rsp, nil
 	}
 
 	f.log.Debug("rendered manifests", "manifests", buf.String())
 
-	// Parse the rendered manifests.
-	data := buf.String()
-	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(data), 1024)
+	// Parse the rendered manifests. Each "---"-delimited document may
+	// override the Function's default renderFormat with its own
+	// "# +format: <format>" header. Every document is validated, even
+	// after one fails, so a single diagnostic can report every error in
+	// the stream at once.
+	defaultFormat := in.RenderFormat
+	if defaultFormat == "" {
+		defaultFormat = v1beta1.YAMLRenderFormat
+	}
+
+	rendered := buf.String()
+	allLines := strings.Split(rendered, "\n")
 
-	if err := decoder.Decode(&rsp); err != nil {
-		response.Fatal(rsp, errors.Wrap(err, "cannot decode manifest"))
+	var docErrs []YamlErrorContext
+	for i, doc := range splitDocumentsWithLines(rendered) {
+		if strings.TrimSpace(doc.text) == "" {
+			continue
+		}
+
+		yamlDoc, err := toYAMLDocument(doc.text, detectDocFormat(doc.text, defaultFormat))
+		if err != nil {
+			docErrs = append(docErrs, newYamlErrorContext(err, i, doc.startLine, allLines))
+			continue
+		}
+
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(yamlDoc), 1024)
+		if err := decoder.Decode(&rsp); err != nil {
+			docErrs = append(docErrs, newYamlErrorContext(err, i, doc.startLine, allLines))
+		}
+	}
+	if len(docErrs) > 0 {
+		response.Fatal(rsp, errors.Errorf("cannot decode manifests:\n%s", formatYamlErrorContexts(docErrs)))
 		return rsp, nil
 	}
 
@@ -140,6 +163,51 @@ func convertToMap(req *fnv1.RunFunctionRequest) (map[string]any, error) {
 	return mReq, nil
 }
 
+// getTemplate returns the parsed template tree for tg and in, reusing a
+// cached tree when f.tmplCache is set and one already exists for this
+// exact template input.
+func (f *Function) getTemplate(tg TemplateSourceGetter, in *v1beta1.GoTemplate) (*template.Template, error) {
+	if f.tmplCache == nil {
+		return buildTemplate(tg, in)
+	}
+
+	key := templateCacheKey(tg, in)
+	if tmpl, ok := f.tmplCache.get(key); ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := buildTemplate(tg, in)
+	if err != nil {
+		return nil, err
+	}
+
+	f.tmplCache.put(key, tmpl)
+	return tmpl, nil
+}
+
+// buildTemplate parses tg's root and named templates and applies in's
+// template options, producing a tree ready to Execute.
+func buildTemplate(tg TemplateSourceGetter, in *v1beta1.GoTemplate) (*template.Template, error) {
+	tmpl, err := GetNewTemplateWithFunctionMaps(in.Delims).Parse(tg.GetTemplates())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid function input: cannot parse the provided templates")
+	}
+
+	for name, body := range tg.GetNamedTemplates() {
+		if _, err := tmpl.New(name).Parse(body); err != nil {
+			return nil, errors.Wrapf(err, "invalid function input: cannot parse template %q", name)
+		}
+	}
+
+	if in.Options != nil {
+		if err := safeApplyTemplateOptions(tmpl, *in.Options); err != nil {
+			return nil, errors.Wrap(err, "cannot apply template options")
+		}
+	}
+
+	return tmpl, nil
+}
+
 func safeApplyTemplateOptions(templ *template.Template, options []string) (err error) {
 	defer func() {
 		rec := recover()
@@ -150,38 +218,3 @@ func safeApplyTemplateOptions(templ *template.Template, options []string) (err e
 	templ.Option(options...)
 	return nil
 }
-
-func moveToNextDoc(lines []string, startLine int) int {
-	for i := startLine; i <= len(lines); i++ {
-		if strings.TrimSpace(lines[i-1]) == "---" && i > startLine {
-			return i
-		}
-	}
-	return startLine
-}
-
-func getYamlErrorContextFromErr(err error, startLine int, lines []string) YamlErrorContext {
-	var relLine int
-	n, scanErr := fmt.Sscanf(err.Error(), "error converting YAML to JSON: yaml: line %d:", &relLine)
-	var errMsg string
-	if scanErr == nil && n == 1 {
-		// Extract the rest of the error message after the matched prefix.
-		prefix := fmt.Sprintf("error converting YAML to JSON: yaml: line %d:", relLine)
-		errStr := err.Error()
-		if idx := strings.Index(errStr, prefix); idx != -1 {
-			errMsg = strings.TrimSpace(errStr[idx+len(prefix):])
-		}
-	}
-	if scanErr == nil && n == 1 {
-		absLine := startLine + relLine
-		if absLine-1 < len(lines) && absLine-1 >= 0 {
-			return YamlErrorContext{
-				RelLine: relLine,
-				AbsLine: absLine,
-				Message: errMsg,
-				Context: lines[absLine-1],
-			}
-		}
-	}
-	return YamlErrorContext{}
-}
@@ -0,0 +1,91 @@
+package funcs
+
+import "text/template"
+
+// CollectionFuncMap returns helpers for combining and querying the
+// map[string]any values a template receives as context.
+func CollectionFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"merge":          merge,
+		"mergeOverwrite": mergeOverwrite,
+		"pluck":          pluck,
+		"dig":            dig,
+	}
+}
+
+// merge recursively copies keys from src into dst without overwriting keys
+// that already exist in dst.
+func merge(dst map[string]any, src ...map[string]any) map[string]any {
+	return mergeInto(dst, false, src...)
+}
+
+// mergeOverwrite recursively copies keys from src into dst, overwriting
+// any keys that already exist in dst.
+func mergeOverwrite(dst map[string]any, src ...map[string]any) map[string]any {
+	return mergeInto(dst, true, src...)
+}
+
+func mergeInto(dst map[string]any, overwrite bool, srcs ...map[string]any) map[string]any {
+	for _, src := range srcs {
+		for k, v := range src {
+			existing, ok := dst[k]
+			if !ok {
+				dst[k] = v
+				continue
+			}
+
+			existingMap, existingIsMap := existing.(map[string]any)
+			valueMap, valueIsMap := v.(map[string]any)
+			if existingIsMap && valueIsMap {
+				dst[k] = mergeInto(existingMap, overwrite, valueMap)
+				continue
+			}
+
+			if overwrite {
+				dst[k] = v
+			}
+		}
+	}
+
+	return dst
+}
+
+// pluck collects the value stored under key from every map in maps,
+// skipping maps where the key is absent.
+func pluck(key string, maps ...map[string]any) []any {
+	out := []any{}
+
+	for _, m := range maps {
+		if v, ok := m[key]; ok {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// dig walks path through nested maps starting at m, returning def if any
+// segment is missing or isn't itself a map. path is variadic, like pluck's
+// maps, so it can be built inline from a template call, e.g.
+// {{ dig "default" $m "a" "b" }}.
+func dig(def any, m map[string]any, path ...string) any {
+	cur := m
+
+	for i, key := range path {
+		v, ok := cur[key]
+		if !ok {
+			return def
+		}
+		if i == len(path)-1 {
+			return v
+		}
+
+		next, ok := v.(map[string]any)
+		if !ok {
+			return def
+		}
+		cur = next
+	}
+
+	return def
+}
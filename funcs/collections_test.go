@@ -0,0 +1,57 @@
+package funcs
+
+import "testing"
+
+func TestMergeDoesNotOverwrite(t *testing.T) {
+	dst := map[string]any{"a": "dst"}
+	src := map[string]any{"a": "src", "b": "src"}
+
+	got := merge(dst, src)
+
+	if got["a"] != "dst" {
+		t.Errorf("merge overwrote existing key: got %v, want %q", got["a"], "dst")
+	}
+	if got["b"] != "src" {
+		t.Errorf("merge did not add new key: got %v, want %q", got["b"], "src")
+	}
+}
+
+func TestMergeOverwrite(t *testing.T) {
+	dst := map[string]any{"a": "dst"}
+	src := map[string]any{"a": "src"}
+
+	got := mergeOverwrite(dst, src)
+
+	if got["a"] != "src" {
+		t.Errorf("mergeOverwrite did not overwrite: got %v, want %q", got["a"], "src")
+	}
+}
+
+func TestPluck(t *testing.T) {
+	maps := []map[string]any{
+		{"name": "a"},
+		{"other": "b"},
+		{"name": "c"},
+	}
+
+	got := pluck("name", maps...)
+	if len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Errorf("pluck(\"name\"): got %v, want [a c]", got)
+	}
+}
+
+func TestDig(t *testing.T) {
+	m := map[string]any{
+		"a": map[string]any{
+			"b": "found",
+		},
+	}
+
+	if got := dig("default", m, "a", "b"); got != "found" {
+		t.Errorf("dig found path: got %v, want %q", got, "found")
+	}
+
+	if got := dig("default", m, "a", "missing"); got != "default" {
+		t.Errorf("dig missing path: got %v, want %q", got, "default")
+	}
+}
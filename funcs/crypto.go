@@ -0,0 +1,130 @@
+package funcs
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // sha1sum/sha256sum are exposed for template use, not for security-sensitive hashing.
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// CryptoFuncMap returns helpers for hashing and generating key material
+// from within a template, mirroring the subset of Sprig's crypto functions
+// Composition authors commonly reach for.
+func CryptoFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"sha1sum":        sha1sum,
+		"sha256sum":      sha256sum,
+		"bcrypt":         bcryptHash,
+		"genPrivateKey":  genPrivateKey,
+		"derivePassword": derivePassword,
+	}
+}
+
+func sha1sum(s string) string {
+	h := sha1.Sum([]byte(s)) //nolint:gosec // see note above.
+	return hex.EncodeToString(h[:])
+}
+
+func sha256sum(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func bcryptHash(s string) string {
+	h, err := bcrypt.GenerateFromPassword([]byte(s), bcrypt.DefaultCost)
+	if err != nil {
+		return ""
+	}
+	return string(h)
+}
+
+// genPrivateKey generates a PEM-encoded private key. Supported types are
+// "rsa" (2048 bit) and "ed25519"; any other value defaults to ed25519.
+func genPrivateKey(keyType string) string {
+	switch keyType {
+	case "rsa":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return ""
+		}
+		return string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}))
+	default:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return ""
+		}
+		b, err := x509.MarshalPKCS8PrivateKey(priv)
+		if err != nil {
+			return ""
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}))
+	}
+}
+
+// passwordCharsets mirrors the character classes Sprig's derivePassword
+// selects between, keyed by the password type argument.
+var passwordCharsets = map[string]string{
+	"maximum": "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()",
+	"long":    "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789",
+	"medium":  "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789",
+	"basic":   "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789",
+	"short":   "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789",
+	"pin":     "0123456789",
+}
+
+// derivePassword deterministically derives a password from a master
+// password and a per-site identity. It's a simple HMAC-SHA256 based KDF
+// rather than a full port of the Master Password algorithm Sprig uses, but
+// exposes the same call signature so existing Helm templates port over
+// unchanged.
+func derivePassword(counter int, passwordType, password, user, site string) string {
+	charset, ok := passwordCharsets[passwordType]
+	if !ok {
+		charset = passwordCharsets["long"]
+	}
+
+	length := 20
+	switch passwordType {
+	case "maximum":
+		length = 32
+	case "medium":
+		length = 14
+	case "basic":
+		length = 12
+	case "short":
+		length = 6
+	case "pin":
+		length = 4
+	}
+
+	seed := fmt.Sprintf("%s|%s|%d", user, site, counter)
+
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write([]byte(seed))
+	sum := mac.Sum(nil)
+
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		b.WriteByte(charset[int(sum[i%len(sum)])%len(charset)])
+		if i%len(sum) == len(sum)-1 {
+			mac.Reset()
+			mac.Write(sum)
+			sum = mac.Sum(nil)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package funcs
+
+import "testing"
+
+func TestSha256sum(t *testing.T) {
+	got := sha256sum("hello")
+	want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if got != want {
+		t.Errorf("sha256sum(%q): got %q, want %q", "hello", got, want)
+	}
+}
+
+func TestBcryptHash(t *testing.T) {
+	h := bcryptHash("s3cret")
+	if h == "" || h == "s3cret" {
+		t.Errorf("bcryptHash returned unexpected value: %q", h)
+	}
+}
+
+func TestGenPrivateKey(t *testing.T) {
+	cases := []string{"rsa", "ed25519", ""}
+	for _, keyType := range cases {
+		key := genPrivateKey(keyType)
+		if key == "" {
+			t.Errorf("genPrivateKey(%q): got empty key", keyType)
+		}
+	}
+}
+
+func TestDerivePasswordDeterministic(t *testing.T) {
+	a := derivePassword(1, "long", "master", "user@example.com", "example.com")
+	b := derivePassword(1, "long", "master", "user@example.com", "example.com")
+	if a != b {
+		t.Errorf("derivePassword is not deterministic: %q != %q", a, b)
+	}
+
+	c := derivePassword(2, "long", "master", "user@example.com", "example.com")
+	if a == c {
+		t.Errorf("derivePassword did not change with counter: %q", a)
+	}
+}
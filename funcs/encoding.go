@@ -0,0 +1,83 @@
+package funcs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"sigs.k8s.io/yaml"
+)
+
+// EncodingFuncMap returns helpers for converting between Go values and the
+// markup formats Compositions are commonly authored in.
+func EncodingFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"b64enc":   b64enc,
+		"b64dec":   b64dec,
+		"toYaml":   toYaml,
+		"fromYaml": fromYaml,
+		"toJson":   toJSON,
+		"fromJson": fromJSON,
+		"toToml":   toTOML,
+	}
+}
+
+func b64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// b64dec decodes a base64 string, returning an empty string if it is not
+// valid base64 rather than aborting the template render.
+func b64dec(s string) string {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func toYaml(v any) string {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// fromYaml decodes a YAML document into a map. Decode errors are returned
+// inline under an "Error" key, matching Helm's fromYaml behaviour, so a
+// broken pipeline fails visibly in the rendered output rather than
+// aborting the whole template.
+func fromYaml(s string) map[string]any {
+	m := map[string]any{}
+	if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+		return map[string]any{"Error": err.Error()}
+	}
+	return m
+}
+
+func toJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func fromJSON(s string) map[string]any {
+	m := map[string]any{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		return map[string]any{"Error": err.Error()}
+	}
+	return m
+}
+
+func toTOML(v any) string {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(v); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,45 @@
+package funcs
+
+import "testing"
+
+func TestB64EncDec(t *testing.T) {
+	enc := b64enc("hello")
+	if dec := b64dec(enc); dec != "hello" {
+		t.Errorf("b64dec(b64enc(%q)): got %q", "hello", dec)
+	}
+}
+
+func TestB64DecInvalid(t *testing.T) {
+	if got := b64dec("not-valid-base64!!"); got != "" {
+		t.Errorf("b64dec with invalid input: got %q, want empty string", got)
+	}
+}
+
+func TestToYamlFromYaml(t *testing.T) {
+	in := map[string]any{"foo": "bar"}
+	y := toYaml(in)
+
+	out := fromYaml(y)
+	if out["foo"] != "bar" {
+		t.Errorf("round-tripped yaml: got %v, want foo=bar", out)
+	}
+}
+
+func TestToJsonFromJson(t *testing.T) {
+	in := map[string]any{"foo": "bar"}
+	j := toJSON(in)
+
+	out := fromJSON(j)
+	if out["foo"] != "bar" {
+		t.Errorf("round-tripped json: got %v, want foo=bar", out)
+	}
+}
+
+func TestToToml(t *testing.T) {
+	in := map[string]any{"foo": "bar"}
+	got := toTOML(in)
+	want := "foo = \"bar\"\n"
+	if got != want {
+		t.Errorf("toToml(%v): got %q, want %q", in, got, want)
+	}
+}
@@ -0,0 +1,30 @@
+// Package funcs provides the helper functions available to Composition
+// templates rendered by this Function. The set is modeled on the
+// Sprig/Helm function library and reuses many of its names, but not every
+// helper matches its Sprig or Helm namesake: derivePassword, dig and
+// lookup, for example, differ in algorithm or arguments.
+package funcs
+
+import "text/template"
+
+// FuncMap returns the full set of helper functions exposed to templates,
+// grouped by category so each family can be extended and tested in
+// isolation.
+func FuncMap() template.FuncMap {
+	fm := template.FuncMap{}
+
+	for _, group := range []template.FuncMap{
+		StringFuncMap(),
+		EncodingFuncMap(),
+		CryptoFuncMap(),
+		KubernetesFuncMap(),
+		SemverFuncMap(),
+		CollectionFuncMap(),
+	} {
+		for name, fn := range group {
+			fm[name] = fn
+		}
+	}
+
+	return fm
+}
@@ -0,0 +1,96 @@
+package funcs
+
+import (
+	"strings"
+	"text/template"
+)
+
+// KubernetesFuncMap returns helpers for reaching into the observed and
+// extraResources maps threaded into a template's root data, and for
+// working with Kubernetes object references.
+func KubernetesFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"lookup":               lookup,
+		"getResourceCondition": getResourceCondition,
+		"parseRef":             parseRef,
+	}
+}
+
+// lookup walks keys through m (typically ".observed" or ".extraResources"),
+// returning nil as soon as a segment is missing rather than panicking, so
+// an optional resource can be referenced safely with an "if" guard.
+func lookup(m map[string]any, keys ...string) any {
+	var cur any = m
+
+	for _, k := range keys {
+		asMap, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur, ok = asMap[k]
+		if !ok {
+			return nil
+		}
+	}
+
+	return cur
+}
+
+// getResourceCondition returns the status of the named condition type on a
+// resource's status.conditions, or an empty string if the resource has no
+// such condition.
+func getResourceCondition(resource map[string]any, conditionType string) string {
+	status, ok := resource["status"].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	conditions, ok := status["conditions"].([]any)
+	if !ok {
+		return ""
+	}
+
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if cond["type"] != conditionType {
+			continue
+		}
+		if s, ok := cond["status"].(string); ok {
+			return s
+		}
+	}
+
+	return ""
+}
+
+// ObjectRef is a parsed Kubernetes object reference.
+type ObjectRef struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// parseRef parses a "<apiVersion>/<kind>/<namespace>/<name>" reference, as
+// used when building extraResources selectors from a template. The
+// namespace segment may be omitted for cluster-scoped resources. The
+// reference is split on every "/", so only an apiVersion without a "/"
+// (a core-group version such as "v1") is parsed into its own field; a
+// grouped apiVersion such as "apps/v1" shifts the segments, and a
+// reference that isn't three or four segments long is returned whole as
+// Name.
+func parseRef(ref string) ObjectRef {
+	parts := strings.Split(ref, "/")
+
+	switch len(parts) {
+	case 4:
+		return ObjectRef{APIVersion: parts[0], Kind: parts[1], Namespace: parts[2], Name: parts[3]}
+	case 3:
+		return ObjectRef{APIVersion: parts[0], Kind: parts[1], Name: parts[2]}
+	default:
+		return ObjectRef{Name: ref}
+	}
+}
@@ -0,0 +1,57 @@
+package funcs
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	m := map[string]any{
+		"observed": map[string]any{
+			"composite": map[string]any{
+				"resource": "found",
+			},
+		},
+	}
+
+	if got := lookup(m, "observed", "composite", "resource"); got != "found" {
+		t.Errorf("lookup found path: got %v, want %q", got, "found")
+	}
+
+	if got := lookup(m, "observed", "missing"); got != nil {
+		t.Errorf("lookup missing path: got %v, want nil", got)
+	}
+}
+
+func TestGetResourceCondition(t *testing.T) {
+	resource := map[string]any{
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True"},
+			},
+		},
+	}
+
+	if got := getResourceCondition(resource, "Ready"); got != "True" {
+		t.Errorf("getResourceCondition(Ready): got %q, want %q", got, "True")
+	}
+
+	if got := getResourceCondition(resource, "Synced"); got != "" {
+		t.Errorf("getResourceCondition(Synced): got %q, want empty string", got)
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	cases := map[string]struct {
+		in   string
+		want ObjectRef
+	}{
+		"Namespaced":    {in: "v1/ConfigMap/default/my-cm", want: ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "my-cm"}},
+		"ClusterScoped": {in: "v1/Namespace/my-ns", want: ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "my-ns"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := parseRef(tc.in); got != tc.want {
+				t.Errorf("parseRef(%q): got %+v, want %+v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
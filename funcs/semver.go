@@ -0,0 +1,37 @@
+package funcs
+
+import (
+	"text/template"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// SemverFuncMap returns helpers for comparing semantic versions, useful
+// when a template needs to branch on a resource's apiVersion or a
+// provider's reported version.
+func SemverFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"semver":        parseSemver,
+		"semverCompare": semverCompare,
+	}
+}
+
+func parseSemver(s string) (*semver.Version, error) {
+	return semver.NewVersion(s)
+}
+
+// semverCompare reports whether v satisfies constraint, e.g.
+// semverCompare ">= 1.2.0" "1.3.0".
+func semverCompare(constraint, v string) (bool, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	ver, err := semver.NewVersion(v)
+	if err != nil {
+		return false, err
+	}
+
+	return c.Check(ver), nil
+}
@@ -0,0 +1,32 @@
+package funcs
+
+import "testing"
+
+func TestSemverCompare(t *testing.T) {
+	cases := map[string]struct {
+		constraint, version string
+		want                bool
+	}{
+		"Satisfies":      {constraint: ">= 1.2.0", version: "1.3.0", want: true},
+		"DoesNotSatisfy": {constraint: ">= 1.2.0", version: "1.1.0", want: false},
+		"ExactMatch":     {constraint: "1.2.0", version: "1.2.0", want: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := semverCompare(tc.constraint, tc.version)
+			if err != nil {
+				t.Fatalf("semverCompare(%q, %q): unexpected error: %v", tc.constraint, tc.version, err)
+			}
+			if got != tc.want {
+				t.Errorf("semverCompare(%q, %q): got %v, want %v", tc.constraint, tc.version, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSemverCompareInvalidConstraint(t *testing.T) {
+	if _, err := semverCompare("not-a-constraint", "1.0.0"); err == nil {
+		t.Error("semverCompare with invalid constraint: expected error, got nil")
+	}
+}
@@ -0,0 +1,72 @@
+package funcs
+
+import (
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// StringFuncMap returns string manipulation helpers, mirroring the subset
+// of Masterminds/sprig functions Helm charts commonly rely on.
+func StringFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"snakecase":  snakecase,
+		"camelcase":  camelcase,
+		"indent":     indent,
+		"nindent":    nindent,
+	}
+}
+
+// indent prefixes every line of s with n spaces.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// nindent behaves like indent, but also prepends a newline - the common
+// case when a value is substituted into an already-indented manifest.
+func nindent(n int, s string) string {
+	return "\n" + indent(n, s)
+}
+
+// snakecase converts CamelCase, kebab-case or space separated input to
+// snake_case.
+func snakecase(s string) string {
+	var b strings.Builder
+
+	for i, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case r == '-' || r == ' ':
+			b.WriteByte('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// camelcase converts snake_case, kebab-case or space separated input to
+// camelCase.
+func camelcase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' || r == ' ' })
+
+	for i, p := range parts {
+		if i == 0 {
+			parts[i] = strings.ToLower(p)
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+	}
+
+	return strings.Join(parts, "")
+}
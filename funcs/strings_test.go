@@ -0,0 +1,46 @@
+package funcs
+
+import "testing"
+
+func TestSnakecase(t *testing.T) {
+	cases := map[string]struct {
+		in, want string
+	}{
+		"CamelCase": {in: "MyFieldName", want: "my_field_name"},
+		"KebabCase": {in: "my-field-name", want: "my_field_name"},
+		"Spaces":    {in: "my field name", want: "my_field_name"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := snakecase(tc.in); got != tc.want {
+				t.Errorf("snakecase(%q): got %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCamelcase(t *testing.T) {
+	cases := map[string]struct {
+		in, want string
+	}{
+		"SnakeCase": {in: "my_field_name", want: "myFieldName"},
+		"KebabCase": {in: "my-field-name", want: "myFieldName"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := camelcase(tc.in); got != tc.want {
+				t.Errorf("camelcase(%q): got %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNindent(t *testing.T) {
+	got := nindent(2, "a\nb")
+	want := "\n  a\n  b"
+	if got != want {
+		t.Errorf("nindent(2, \"a\\nb\"): got %q, want %q", got, want)
+	}
+}
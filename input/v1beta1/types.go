@@ -0,0 +1,96 @@
+// Package v1beta1 contains the input type for this Function.
+// +kubebuilder:object:generate=true
+// +groupName=template.fn.crossplane.io
+// +versionName=v1beta1
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Source determines where the Function loads its templates from.
+type Source string
+
+const (
+	// FileSystemSource loads templates from the directory mounted into the
+	// Function's container.
+	FileSystemSource Source = "FileSystem"
+	// InlineSource loads templates directly from the Inline field.
+	InlineSource Source = "Inline"
+	// RemoteSource fetches a single template document from the URL set in
+	// the Remote field.
+	RemoteSource Source = "Remote"
+)
+
+// Delims overrides the default "{{"/"}}" Go template action delimiters.
+type Delims struct {
+	Left  string `json:"left,omitempty"`
+	Right string `json:"right,omitempty"`
+}
+
+// RenderFormat is the serialization format a rendered document is decoded
+// as.
+type RenderFormat string
+
+const (
+	// YAMLRenderFormat decodes a document as YAML (or JSON, which is a
+	// subset of YAML). This is the default.
+	YAMLRenderFormat RenderFormat = "yaml"
+	// JSONRenderFormat decodes a document as JSON.
+	JSONRenderFormat RenderFormat = "json"
+	// TOMLRenderFormat decodes a document as TOML.
+	TOMLRenderFormat RenderFormat = "toml"
+)
+
+// GoTemplate can be used to provide a Go template to render composed
+// resources.
+// +kubebuilder:object:root=true
+type GoTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Source of the templates.
+	// +kubebuilder:validation:Enum=Inline;FileSystem;Remote
+	Source Source `json:"source"`
+
+	// Inline contains the template when Source is InlineSource.
+	// +optional
+	Inline *string `json:"inline,omitempty"`
+
+	// Remote is the URL a template is fetched from when Source is
+	// RemoteSource.
+	// +optional
+	Remote *string `json:"remote,omitempty"`
+
+	// Templates optionally provides additional named templates, keyed by
+	// name, that are parsed alongside the primary Source and can be
+	// referenced from it (or from each other) with
+	// `{{ template "name" . }}`, in the style of a Helm chart's
+	// _helpers.tpl.
+	// +optional
+	Templates map[string]string `json:"templates,omitempty"`
+
+	// Entrypoint names the template that should be executed. When it's
+	// unset, the root template (the Inline template, the Remote document,
+	// or the FileSystem source's files concatenated) is executed
+	// anonymously instead.
+	// +optional
+	Entrypoint *string `json:"entrypoint,omitempty"`
+
+	// Delims overrides the default Go template delimiters.
+	// +optional
+	Delims *Delims `json:"delims,omitempty"`
+
+	// Options sets the Go template options, as accepted by
+	// text/template.Template.Option.
+	// +optional
+	Options *[]string `json:"options,omitempty"`
+
+	// RenderFormat is the default serialization format rendered documents
+	// are decoded as. A document may override this with a
+	// "# +format: <format>" header at its top, after the "---" divider
+	// that opens it and before any non-comment line. Defaults to
+	// YAMLRenderFormat.
+	// +kubebuilder:validation:Enum=yaml;json;toml
+	// +optional
+	RenderFormat RenderFormat `json:"renderFormat,omitempty"`
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/function-go-templating/input/v1beta1"
+)
+
+// LintFinding is a single problem found while linting a set of templates.
+type LintFinding struct {
+	// Kind categorizes the finding: "parse-error", "unknown-function",
+	// "undefined-variable", "exec-error", or "schema-violation".
+	Kind string
+	// Message describes the finding, including source context when one
+	// is available.
+	Message string
+}
+
+// LintReport is everything LintCmd found wrong with a set of templates.
+type LintReport struct {
+	Findings []LintFinding
+}
+
+func (r *LintReport) add(kind, format string, args ...any) {
+	r.Findings = append(r.Findings, LintFinding{Kind: kind, Message: fmt.Sprintf(format, args...)})
+}
+
+// LintCmd runs the same pipeline RunFunction does - parsing, execution,
+// and manifest decoding - against a directory or file of templates and a
+// sample request, so Composition authors can catch mistakes without a
+// running Crossplane control plane.
+type LintCmd struct {
+	TemplatesPath string `arg:"" help:"Directory or file of templates to lint."`
+
+	Request    string `optional:"" help:"Path to a sample request data document (YAML or JSON) used as the template's root data. An empty map is used if omitted."`
+	Entrypoint string `optional:"" help:"Template to execute, matching GoTemplate.Entrypoint. The root template is executed if omitted."`
+	CRDsDir    string `optional:"" help:"Directory of CustomResourceDefinition YAML files to validate rendered resources' required fields against."`
+}
+
+// Run this command.
+func (c *LintCmd) Run() error {
+	report, err := c.lint()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range report.Findings {
+		fmt.Printf("[%s] %s\n", f.Kind, f.Message)
+	}
+
+	if len(report.Findings) > 0 {
+		return errors.Errorf("found %d issue(s)", len(report.Findings))
+	}
+
+	fmt.Println("no issues found")
+	return nil
+}
+
+func (c *LintCmd) lint() (*LintReport, error) {
+	report := &LintReport{}
+
+	in, fsys, err := c.templateInput()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read templates")
+	}
+
+	tg, err := NewTemplateSourceGetter(fsys, nil, in)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid templates")
+	}
+
+	tmpl, err := GetNewTemplateWithFunctionMaps(nil).Parse(tg.GetTemplates())
+	if err != nil {
+		report.add(findingKindForParseErr(err), "root template: %s", err)
+		return report, nil
+	}
+
+	for name, body := range tg.GetNamedTemplates() {
+		if _, err := tmpl.New(name).Parse(body); err != nil {
+			report.add(findingKindForParseErr(err), "template %q: %s", name, err)
+		}
+	}
+	if len(report.Findings) > 0 {
+		// A template that doesn't parse can't be executed or validated
+		// any further.
+		return report, nil
+	}
+
+	// missingkey=error turns a reference to an undefined map key into an
+	// execution error we can report, instead of silently rendering
+	// "<no value>".
+	if err := safeApplyTemplateOptions(tmpl, []string{"missingkey=error"}); err != nil {
+		return nil, errors.Wrap(err, "cannot apply template options")
+	}
+
+	reqMap, err := c.requestMap()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read sample request")
+	}
+
+	buf := &bytes.Buffer{}
+	if c.Entrypoint != "" {
+		err = tmpl.ExecuteTemplate(buf, c.Entrypoint, reqMap)
+	} else {
+		err = tmpl.Execute(buf, reqMap)
+	}
+	if err != nil {
+		if tc, ok := newTemplateErrorContext(err, templateSources(tg)); ok {
+			report.add(findingKindForExecErr(tc.Message), "%s\n%s", tc.Message, tc.Snippet)
+		} else {
+			report.add("exec-error", "%s", err)
+		}
+		return report, nil
+	}
+
+	var crds []crd
+	if c.CRDsDir != "" {
+		crds, err = loadCRDs(c.CRDsDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot load CRDs")
+		}
+	}
+
+	rendered := buf.String()
+	allLines := strings.Split(rendered, "\n")
+
+	for i, doc := range splitDocumentsWithLines(rendered) {
+		if strings.TrimSpace(doc.text) == "" {
+			continue
+		}
+
+		yamlDoc, err := toYAMLDocument(doc.text, detectDocFormat(doc.text, v1beta1.YAMLRenderFormat))
+		if err != nil {
+			yc := newYamlErrorContext(err, i, doc.startLine, allLines)
+			report.add("parse-error", "document %d, line %d: %s\n%s", yc.DocIndex, yc.AbsLine, yc.Message, yc.Snippet)
+			continue
+		}
+
+		var obj map[string]any
+		if err := sigsyaml.Unmarshal([]byte(yamlDoc), &obj); err != nil {
+			yc := newYamlErrorContext(err, i, doc.startLine, allLines)
+			report.add("parse-error", "document %d, line %d: %s\n%s", yc.DocIndex, yc.AbsLine, yc.Message, yc.Snippet)
+			continue
+		}
+
+		for _, violation := range validateAgainstCRDs(obj, crds) {
+			report.add("schema-violation", "document %d: %s", i, violation)
+		}
+	}
+
+	return report, nil
+}
+
+// templateInput reads TemplatesPath into the GoTemplate input and fs.FS
+// RunFunction would have used to produce the same TemplateSourceGetter -
+// FileSystemSource for a directory, InlineSource for a single file.
+func (c *LintCmd) templateInput() (*v1beta1.GoTemplate, fs.FS, error) {
+	info, err := os.Stat(c.TemplatesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := &v1beta1.GoTemplate{}
+	if c.Entrypoint != "" {
+		in.Entrypoint = &c.Entrypoint
+	}
+
+	if info.IsDir() {
+		in.Source = v1beta1.FileSystemSource
+		return in, os.DirFS(c.TemplatesPath), nil
+	}
+
+	b, err := os.ReadFile(c.TemplatesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inline := string(b)
+	in.Source = v1beta1.InlineSource
+	in.Inline = &inline
+
+	return in, nil, nil
+}
+
+// requestMap reads Request as the map[string]any threaded into the
+// template as its root data, or an empty map if no Request was given.
+func (c *LintCmd) requestMap() (map[string]any, error) {
+	if c.Request == "" {
+		return map[string]any{}, nil
+	}
+
+	b, err := os.ReadFile(c.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := sigsyaml.Unmarshal(b, &m); err != nil {
+		return nil, errors.Wrap(err, "cannot parse sample request")
+	}
+
+	return m, nil
+}
+
+// findingKindForParseErr classifies a text/template parse error as
+// "unknown-function" when it names an undefined function, or
+// "parse-error" otherwise.
+func findingKindForParseErr(err error) string {
+	if strings.Contains(err.Error(), "not defined") {
+		return "unknown-function"
+	}
+	return "parse-error"
+}
+
+// findingKindForExecErr classifies a template execution error as
+// "undefined-variable" when missingkey=error rejected a missing map key,
+// or "exec-error" otherwise.
+func findingKindForExecErr(msg string) string {
+	if strings.Contains(msg, "map has no entry for key") {
+		return "undefined-variable"
+	}
+	return "exec-error"
+}
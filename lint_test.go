@@ -0,0 +1,126 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindingKindForParseErr(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"unknown function": {
+			err:  errorString(`template: :1: function "frobnicate" not defined`),
+			want: "unknown-function",
+		},
+		"other parse error": {
+			err:  errorString(`template: :1: unexpected "}" in command`),
+			want: "parse-error",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := findingKindForParseErr(tc.err); got != tc.want {
+				t.Errorf("findingKindForParseErr: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindingKindForExecErr(t *testing.T) {
+	cases := map[string]struct {
+		msg  string
+		want string
+	}{
+		"missing key": {
+			msg:  `map has no entry for key "missing"`,
+			want: "undefined-variable",
+		},
+		"other exec error": {
+			msg:  "some other failure",
+			want: "exec-error",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := findingKindForExecErr(tc.msg); got != tc.want {
+				t.Errorf("findingKindForExecErr: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLintCmdDetectsIssues(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "composite.yaml")
+	if err := os.WriteFile(path, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .missing.field }}\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+
+	c := &LintCmd{TemplatesPath: path}
+
+	report, err := c.lint()
+	if err != nil {
+		t.Fatalf("lint: unexpected error: %v", err)
+	}
+
+	if len(report.Findings) != 1 {
+		t.Fatalf("lint: got %d findings, want 1: %+v", len(report.Findings), report.Findings)
+	}
+	if report.Findings[0].Kind != "undefined-variable" {
+		t.Errorf("lint: got kind %q, want %q", report.Findings[0].Kind, "undefined-variable")
+	}
+}
+
+func TestLintCmdClean(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "composite.yaml")
+	if err := os.WriteFile(path, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+
+	c := &LintCmd{TemplatesPath: path}
+
+	report, err := c.lint()
+	if err != nil {
+		t.Fatalf("lint: unexpected error: %v", err)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("lint: got %d findings, want 0: %+v", len(report.Findings), report.Findings)
+	}
+}
+
+func TestValidateAgainstCRDs(t *testing.T) {
+	crds := []crd{{Spec: crdSpec{
+		Group: "example.org",
+		Names: crdNames{Kind: "Widget"},
+		Versions: []crdVersion{{
+			Name: "v1alpha1",
+			Schema: crdSchema{OpenAPIV3Schema: crdOpenAPISchema{
+				Properties: map[string]crdProperty{
+					"spec": {Required: []string{"size"}},
+				},
+			}},
+		}},
+	}}}
+
+	obj := map[string]any{
+		"apiVersion": "example.org/v1alpha1",
+		"kind":       "Widget",
+		"spec":       map[string]any{},
+	}
+
+	violations := validateAgainstCRDs(obj, crds)
+	if len(violations) != 1 {
+		t.Fatalf("validateAgainstCRDs: got %d violations, want 1: %v", len(violations), violations)
+	}
+
+	obj["spec"] = map[string]any{"size": "large"}
+	if violations := validateAgainstCRDs(obj, crds); len(violations) != 0 {
+		t.Errorf("validateAgainstCRDs: got %d violations, want 0: %v", len(violations), violations)
+	}
+}
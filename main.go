@@ -0,0 +1,14 @@
+package main
+
+import "github.com/alecthomas/kong"
+
+// CLI is the entrypoint of the function-go-templating binary.
+var CLI struct {
+	Serve ServeCmd `cmd:"" default:"withargs" help:"Start the Function as a gRPC server. This is the default command."`
+	Lint  LintCmd  `cmd:"" help:"Lint a directory or file of templates against a sample request, without a running Crossplane control plane."`
+}
+
+func main() {
+	ctx := kong.Parse(&CLI, kong.Description("A Crossplane Function that uses Go templates to compose resources."))
+	ctx.FatalIfErrorf(ctx.Run())
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/crossplane/function-sdk-go/errors"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/function-go-templating/input/v1beta1"
+)
+
+// formatMarkerPrefix marks a rendered document's serialization format,
+// e.g. "# +format: toml", when it differs from the Function's
+// RenderFormat default.
+const formatMarkerPrefix = "# +format:"
+
+// yamlDocument is a single "---"-delimited document along with the
+// 1-based line it starts on within the full rendered manifest stream, so
+// a decode error in it can be reported against an absolute line number.
+type yamlDocument struct {
+	text      string
+	startLine int
+}
+
+// splitDocumentsWithLines splits data into the "---"-delimited documents
+// it contains, using moveToNextDoc so the split stays consistent with the
+// line numbers reported in YAML error diagnostics.
+func splitDocumentsWithLines(data string) []yamlDocument {
+	lines := strings.Split(data, "\n")
+
+	var docs []yamlDocument
+	for start := 1; start <= len(lines); {
+		next := moveToNextDoc(lines, start)
+		if next == start {
+			docs = append(docs, yamlDocument{text: strings.Join(lines[start-1:], "\n"), startLine: start})
+			break
+		}
+		docs = append(docs, yamlDocument{text: strings.Join(lines[start-1:next-1], "\n"), startLine: start})
+		start = next + 1
+	}
+
+	return docs
+}
+
+// detectDocFormat returns the format named by a "# +format: <format>"
+// header at the top of doc, or fallback if doc has no such header.
+func detectDocFormat(doc string, fallback v1beta1.RenderFormat) v1beta1.RenderFormat {
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if rest, ok := strings.CutPrefix(line, formatMarkerPrefix); ok {
+			return v1beta1.RenderFormat(strings.TrimSpace(rest))
+		}
+	}
+
+	return fallback
+}
+
+// toYAMLDocument normalizes doc, rendered in the given format, to YAML (or
+// JSON, which [k8s.io/apimachinery/pkg/util/yaml.NewYAMLOrJSONDecoder]
+// also accepts) so it can be decoded with the existing manifest decoder
+// regardless of the format it was authored in.
+func toYAMLDocument(doc string, format v1beta1.RenderFormat) (string, error) {
+	switch format {
+	case v1beta1.TOMLRenderFormat:
+		m := map[string]any{}
+		if _, err := toml.Decode(doc, &m); err != nil {
+			return "", errors.Wrap(err, "cannot decode document as TOML")
+		}
+		b, err := sigsyaml.Marshal(m)
+		if err != nil {
+			return "", errors.Wrap(err, "cannot convert TOML document to YAML")
+		}
+		return string(b), nil
+	case v1beta1.JSONRenderFormat, v1beta1.YAMLRenderFormat, "":
+		return doc, nil
+	default:
+		return "", errors.Errorf("unknown renderFormat %q", format)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/function-go-templating/input/v1beta1"
+)
+
+func TestSplitDocumentsWithLines(t *testing.T) {
+	data := "a: 1\n---\nb: 2\n---\nc: 3\n"
+
+	docs := splitDocumentsWithLines(data)
+	if len(docs) != 3 {
+		t.Fatalf("splitDocumentsWithLines: got %d documents, want 3: %v", len(docs), docs)
+	}
+
+	wantText := []string{"a: 1", "b: 2", "c: 3\n"}
+	wantStartLine := []int{1, 3, 5}
+	for i, doc := range docs {
+		if doc.text != wantText[i] {
+			t.Errorf("docs[%d].text: got %q, want %q", i, doc.text, wantText[i])
+		}
+		if doc.startLine != wantStartLine[i] {
+			t.Errorf("docs[%d].startLine: got %d, want %d", i, doc.startLine, wantStartLine[i])
+		}
+	}
+}
+
+func TestDetectDocFormat(t *testing.T) {
+	cases := map[string]struct {
+		doc      string
+		fallback v1beta1.RenderFormat
+		want     v1beta1.RenderFormat
+	}{
+		"marker": {
+			doc:      "# +format: toml\nkey = \"value\"\n",
+			fallback: v1beta1.YAMLRenderFormat,
+			want:     v1beta1.TOMLRenderFormat,
+		},
+		"no marker falls back": {
+			doc:      "a: 1\n",
+			fallback: v1beta1.YAMLRenderFormat,
+			want:     v1beta1.YAMLRenderFormat,
+		},
+		"marker must precede non-comment lines": {
+			doc:      "a: 1\n# +format: toml\n",
+			fallback: v1beta1.YAMLRenderFormat,
+			want:     v1beta1.YAMLRenderFormat,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := detectDocFormat(tc.doc, tc.fallback)
+			if got != tc.want {
+				t.Errorf("detectDocFormat: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToYAMLDocument(t *testing.T) {
+	toml := "key = \"value\"\n"
+
+	out, err := toYAMLDocument(toml, v1beta1.TOMLRenderFormat)
+	if err != nil {
+		t.Fatalf("toYAMLDocument: unexpected error: %v", err)
+	}
+	if out != "key: value\n" {
+		t.Errorf("toYAMLDocument: got %q, want %q", out, "key: value\n")
+	}
+
+	if _, err := toYAMLDocument("a: 1\n", v1beta1.RenderFormat("xml")); err == nil {
+		t.Error("toYAMLDocument: expected error for unknown format, got nil")
+	}
+}
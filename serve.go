@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+
+	function "github.com/crossplane/function-sdk-go"
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/logging"
+)
+
+// ServeCmd starts the Function as a gRPC server. This is what Crossplane
+// runs in production.
+type ServeCmd struct {
+	Debug bool `env:"DEBUG" help:"Emit debug logs."`
+
+	Network     string `default:"tcp" help:"Network on which to listen for gRPC connections."`
+	Address     string `default:":9443" help:"Address at which to listen for gRPC connections."`
+	TLSCertsDir string `env:"TLS_SERVER_CERTS_DIR" help:"Serve using mTLS certificates from this directory."`
+	Insecure    bool   `help:"Run without mTLS credentials. If you supply this flag, TLSCertsDir is ignored."`
+
+	TemplatesDir string `default:"/templates" help:"Directory to load templates from, when Source is FileSystem."`
+	NoCache      bool   `help:"Disable the filesystem and parsed-template caches. Every RunFunction call re-reads template sources from disk and re-parses them, which is slower but picks up edits immediately."`
+}
+
+// Run this command.
+func (c *ServeCmd) Run() error {
+	log, err := logging.NewLogger(c.Debug)
+	if err != nil {
+		return errors.Wrap(err, "cannot create logger")
+	}
+
+	f := &Function{log: log}
+
+	if c.NoCache {
+		f.fsys = os.DirFS(c.TemplatesDir)
+	} else {
+		cfs, err := newCachedFS(c.TemplatesDir, log)
+		if err != nil {
+			return errors.Wrap(err, "cannot set up cached filesystem")
+		}
+		defer cfs.Close() //nolint:errcheck // nothing useful to do with a close error on shutdown.
+
+		f.fsys = cfs
+		f.tmplCache = newTemplateCache()
+	}
+
+	log.Info("Starting Function", "no-cache", c.NoCache, "templates-dir", c.TemplatesDir)
+
+	if c.Insecure {
+		return function.Serve(f, function.Listen(c.Network, c.Address), function.Insecure(true))
+	}
+	return function.Serve(f, function.Listen(c.Network, c.Address), function.MTLSCertificates(c.TLSCertsDir))
+}
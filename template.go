@@ -0,0 +1,217 @@
+package main
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/function-sdk-go/errors"
+
+	"github.com/crossplane-contrib/function-go-templating/funcs"
+	"github.com/crossplane-contrib/function-go-templating/input/v1beta1"
+)
+
+// TemplateSourceGetter returns the template source that should be parsed
+// and executed for a single RunFunction call.
+type TemplateSourceGetter interface {
+	// GetTemplates returns the root template body, parsed as an anonymous
+	// template. It's executed directly when the input sets no entrypoint.
+	GetTemplates() string
+
+	// GetNamedTemplates returns any additional named (sub)templates that
+	// should be registered alongside the root template, keyed by name.
+	// This is how a Composition author splits a set of resources across
+	// multiple files or map entries and references one from another with
+	// "{{ template "name" . }}", in the style of a Helm chart's
+	// _helpers.tpl.
+	GetNamedTemplates() map[string]string
+}
+
+// namedTemplateSourceGetter is a generic TemplateSourceGetter backed by a
+// root template body plus a set of named subtemplates.
+type namedTemplateSourceGetter struct {
+	root  string
+	named map[string]string
+}
+
+// GetTemplates implements TemplateSourceGetter.
+func (g *namedTemplateSourceGetter) GetTemplates() string {
+	return g.root
+}
+
+// GetNamedTemplates implements TemplateSourceGetter.
+func (g *namedTemplateSourceGetter) GetNamedTemplates() map[string]string {
+	return g.named
+}
+
+// remoteFetchTimeout bounds how long NewTemplateSourceGetter waits when
+// Source is RemoteSource.
+const remoteFetchTimeout = 10 * time.Second
+
+// NewTemplateSourceGetter returns the TemplateSourceGetter appropriate for
+// the Source set on the supplied GoTemplate input. reqContext is the
+// Composition Function request's context, reserved for source modes (e.g.
+// ConfigMap) that need to resolve data passed alongside the request.
+func NewTemplateSourceGetter(fsys fs.FS, reqContext *structpb.Struct, in *v1beta1.GoTemplate) (TemplateSourceGetter, error) {
+	_ = reqContext
+
+	var root string
+	named := map[string]string{}
+
+	switch in.Source {
+	case v1beta1.InlineSource:
+		if in.Inline == nil {
+			return nil, errNoInlineTemplate
+		}
+		root = *in.Inline
+	case v1beta1.FileSystemSource:
+		fromFS, err := loadNamedTemplatesFromFS(fsys)
+		if err != nil {
+			return nil, err
+		}
+		for name, body := range fromFS {
+			named[name] = body
+		}
+		root = strings.Join(sortedValues(fromFS), "\n---\n")
+	case v1beta1.RemoteSource:
+		if in.Remote == nil {
+			return nil, errNoRemoteTemplate
+		}
+		body, err := fetchRemoteTemplate(*in.Remote)
+		if err != nil {
+			return nil, err
+		}
+		root = body
+		named[strings.TrimSuffix(filepath.Base(*in.Remote), filepath.Ext(*in.Remote))] = body
+	default:
+		return nil, errUnknownSource
+	}
+
+	// Inline named templates are merged in on top of whatever the primary
+	// Source produced, letting authors supply shared helpers (e.g. a
+	// "labels" partial) regardless of where the root template comes from.
+	for name, body := range in.Templates {
+		named[name] = body
+	}
+
+	return &namedTemplateSourceGetter{root: root, named: named}, nil
+}
+
+// loadNamedTemplatesFromFS reads every file in fsys, keyed by its path
+// relative to fsys with its extension stripped, so each becomes an
+// independently addressable subtemplate. The full path is used, rather
+// than just the basename, so that two files with the same name in
+// different subdirectories (e.g. "resources/labels.yaml" and
+// "helpers/labels.yaml") don't clobber each other.
+func loadNamedTemplatesFromFS(fsys fs.FS) (map[string]string, error) {
+	named := map[string]string{}
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(path, filepath.Ext(path))
+		named[name] = string(b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return named, nil
+}
+
+// fetchRemoteTemplate fetches a single template document over HTTP(S).
+func fetchRemoteTemplate(url string) (string, error) {
+	client := &http.Client{Timeout: remoteFetchTimeout}
+
+	resp, err := client.Get(url) //nolint:gosec,noctx // the URL is operator supplied Function input, not user input.
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot fetch remote template %q", url)
+	}
+	defer resp.Body.Close() //nolint:errcheck // nothing useful to do with a close error here.
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("cannot fetch remote template %q: unexpected status %q", url, resp.Status)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot read remote template %q", url)
+	}
+
+	return string(b), nil
+}
+
+// sortedValues returns the values of m, ordered by key, so that
+// concatenating a filesystem's files into a single root template is
+// deterministic across calls.
+func sortedValues(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, m[k])
+	}
+
+	return out
+}
+
+// rootTemplateName is the name GetNewTemplateWithFunctionMaps gives the
+// root template, and so the name text/template reports in a
+// "template: <name>:<line>: ..." error for the root template (as opposed
+// to one of its named subtemplates).
+const rootTemplateName = "composition-template"
+
+// GetNewTemplateWithFunctionMaps returns a new, unparsed template with the
+// Function's full helper FuncMap registered, and the supplied delimiters
+// applied if set.
+func GetNewTemplateWithFunctionMaps(delims *v1beta1.Delims) *template.Template {
+	tmpl := template.New(rootTemplateName).Funcs(funcs.FuncMap())
+
+	if delims != nil && delims.Left != "" && delims.Right != "" {
+		tmpl = tmpl.Delims(delims.Left, delims.Right)
+	}
+
+	return tmpl
+}
+
+// templateSources returns tg's root and named template bodies keyed by
+// the template name text/template reports them under, so a parse or exec
+// error naming a template can be mapped back to its source.
+func templateSources(tg TemplateSourceGetter) map[string]string {
+	sources := map[string]string{rootTemplateName: tg.GetTemplates()}
+	for name, body := range tg.GetNamedTemplates() {
+		sources[name] = body
+	}
+
+	return sources
+}
+
+var (
+	errNoInlineTemplate = templateSourceError("source is Inline but no inline template was provided")
+	errNoRemoteTemplate = templateSourceError("source is Remote but no remote URL was provided")
+	errUnknownSource    = templateSourceError("unknown template source")
+)
+
+type templateSourceError string
+
+func (e templateSourceError) Error() string { return string(e) }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"strconv"
+	"sync"
+	"text/template"
+
+	"github.com/crossplane-contrib/function-go-templating/input/v1beta1"
+)
+
+// templateCache caches parsed *template.Template trees keyed by a hash of
+// the sources, delimiters, and options that produced them. Crossplane
+// calls RunFunction once per Composite resource per reconcile, usually
+// with the same template input every time, so reusing the parsed tree
+// instead of re-parsing it from scratch cuts per-request CPU
+// significantly. A cached *template.Template is never mutated after it's
+// stored, so it's safe to Execute concurrently across requests.
+type templateCache struct {
+	mu      sync.RWMutex
+	entries map[string]*template.Template
+}
+
+// newTemplateCache returns an empty templateCache.
+func newTemplateCache() *templateCache {
+	return &templateCache{entries: map[string]*template.Template{}}
+}
+
+func (c *templateCache) get(key string) (*template.Template, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	t, ok := c.entries[key]
+	return t, ok
+}
+
+func (c *templateCache) put(key string, t *template.Template) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = t
+}
+
+// writeHashField writes s to h, length-prefixed so that concatenating
+// two fields' bytes can never produce the same stream as a different
+// split of the same total content (e.g. name "ab" + body "X" versus name
+// "a" + body "bX").
+func writeHashField(h hash.Hash, s string) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(s)))
+	_, _ = h.Write(length[:])
+	_, _ = h.Write([]byte(s))
+}
+
+// templateCacheKey hashes tg's root and named templates, plus in's Delims
+// and Options, into a cache key that's stable across calls with
+// identical template input and changes whenever any of it does.
+func templateCacheKey(tg TemplateSourceGetter, in *v1beta1.GoTemplate) string {
+	h := sha256.New()
+
+	writeHashField(h, tg.GetTemplates())
+
+	named := tg.GetNamedTemplates()
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// Each section is counted or tagged so that, for example, a named
+	// template can't hash the same as a pair of delimiters.
+	writeHashField(h, strconv.Itoa(len(names)))
+	for _, name := range names {
+		writeHashField(h, name)
+		writeHashField(h, named[name])
+	}
+
+	if in.Delims != nil {
+		writeHashField(h, "delims")
+		writeHashField(h, in.Delims.Left)
+		writeHashField(h, in.Delims.Right)
+	}
+
+	if in.Options != nil {
+		opts := append([]string(nil), *in.Options...)
+		sort.Strings(opts)
+		writeHashField(h, "options")
+		for _, opt := range opts {
+			writeHashField(h, opt)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
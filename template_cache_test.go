@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/function-go-templating/input/v1beta1"
+)
+
+func TestTemplateCacheGetPut(t *testing.T) {
+	c := newTemplateCache()
+
+	if _, ok := c.get("missing"); ok {
+		t.Error("get: expected no entry for an unset key")
+	}
+
+	tmpl, err := buildTemplate(&namedTemplateSourceGetter{root: "a: 1\n"}, &v1beta1.GoTemplate{})
+	if err != nil {
+		t.Fatalf("buildTemplate: unexpected error: %v", err)
+	}
+
+	c.put("key", tmpl)
+
+	got, ok := c.get("key")
+	if !ok {
+		t.Fatal("get: expected an entry for \"key\"")
+	}
+	if got != tmpl {
+		t.Error("get: returned a different *template.Template than was put")
+	}
+}
+
+func TestTemplateCacheKeyStability(t *testing.T) {
+	tg := &namedTemplateSourceGetter{
+		root:  "a: 1\n",
+		named: map[string]string{"labels": `{{ define "labels" }}app: test{{ end }}`},
+	}
+	in := &v1beta1.GoTemplate{Delims: &v1beta1.Delims{Left: "[[", Right: "]]"}}
+
+	if templateCacheKey(tg, in) != templateCacheKey(tg, in) {
+		t.Error("templateCacheKey: identical input produced different keys")
+	}
+
+	other := &v1beta1.GoTemplate{Delims: &v1beta1.Delims{Left: "<<", Right: ">>"}}
+	if templateCacheKey(tg, in) == templateCacheKey(tg, other) {
+		t.Error("templateCacheKey: different delims produced the same key")
+	}
+}
+
+// TestTemplateCacheKeyNoAmbiguousSplit proves that two template sets
+// whose name/body fields split the same concatenated bytes differently
+// - "ab" + "X" versus "a" + "bX" - don't collide, which would otherwise
+// return a cache hit for the wrong Composition's parsed template tree.
+func TestTemplateCacheKeyNoAmbiguousSplit(t *testing.T) {
+	in := &v1beta1.GoTemplate{}
+
+	a := &namedTemplateSourceGetter{root: "R", named: map[string]string{"ab": "X"}}
+	b := &namedTemplateSourceGetter{root: "R", named: map[string]string{"a": "bX"}}
+
+	if templateCacheKey(a, in) == templateCacheKey(b, in) {
+		t.Error("templateCacheKey: distinct name/body splits produced the same key")
+	}
+}
+
+func BenchmarkGetTemplateUncached(b *testing.B) {
+	tg := &namedTemplateSourceGetter{root: "a: 1\n---\nb: 2\n"}
+	in := &v1beta1.GoTemplate{}
+	f := &Function{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.getTemplate(tg, in); err != nil {
+			b.Fatalf("getTemplate: unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkGetTemplateCached(b *testing.B) {
+	tg := &namedTemplateSourceGetter{root: "a: 1\n---\nb: 2\n"}
+	in := &v1beta1.GoTemplate{}
+	f := &Function{tmplCache: newTemplateCache()}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.getTemplate(tg, in); err != nil {
+			b.Fatalf("getTemplate: unexpected error: %v", err)
+		}
+	}
+}
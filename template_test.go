@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/crossplane-contrib/function-go-templating/input/v1beta1"
+)
+
+func TestNewTemplateSourceGetterFileSystem(t *testing.T) {
+	fsys := fstest.MapFS{
+		"composite.yaml": &fstest.MapFile{Data: []byte("apiVersion: v1\nkind: ConfigMap\n")},
+		"helpers.yaml":   &fstest.MapFile{Data: []byte(`{{- define "labels" }}app: test{{ end -}}`)},
+	}
+
+	in := &v1beta1.GoTemplate{Source: v1beta1.FileSystemSource}
+
+	tg, err := NewTemplateSourceGetter(fsys, nil, in)
+	if err != nil {
+		t.Fatalf("NewTemplateSourceGetter: unexpected error: %v", err)
+	}
+
+	named := tg.GetNamedTemplates()
+	if _, ok := named["composite"]; !ok {
+		t.Errorf("GetNamedTemplates: missing %q, got %v", "composite", named)
+	}
+	if _, ok := named["helpers"]; !ok {
+		t.Errorf("GetNamedTemplates: missing %q, got %v", "helpers", named)
+	}
+}
+
+func TestNewTemplateSourceGetterFileSystemSubdirectories(t *testing.T) {
+	fsys := fstest.MapFS{
+		"resources/labels.yaml": &fstest.MapFile{Data: []byte(`{{- define "resources-labels" }}app: resources{{ end -}}`)},
+		"helpers/labels.yaml":   &fstest.MapFile{Data: []byte(`{{- define "helpers-labels" }}app: helpers{{ end -}}`)},
+	}
+
+	in := &v1beta1.GoTemplate{Source: v1beta1.FileSystemSource}
+
+	tg, err := NewTemplateSourceGetter(fsys, nil, in)
+	if err != nil {
+		t.Fatalf("NewTemplateSourceGetter: unexpected error: %v", err)
+	}
+
+	named := tg.GetNamedTemplates()
+	if len(named) != 2 {
+		t.Fatalf("GetNamedTemplates: got %d templates, want 2: %v", len(named), named)
+	}
+	if _, ok := named["resources/labels"]; !ok {
+		t.Errorf("GetNamedTemplates: missing %q, got %v", "resources/labels", named)
+	}
+	if _, ok := named["helpers/labels"]; !ok {
+		t.Errorf("GetNamedTemplates: missing %q, got %v", "helpers/labels", named)
+	}
+}
+
+func TestNewTemplateSourceGetterInlineTemplatesMap(t *testing.T) {
+	in := &v1beta1.GoTemplate{
+		Source: v1beta1.InlineSource,
+		Inline: strPtr(`{{ template "labels" . }}`),
+		Templates: map[string]string{
+			"labels": `{{- define "labels" }}app: test{{ end -}}`,
+		},
+	}
+
+	tg, err := NewTemplateSourceGetter(nil, nil, in)
+	if err != nil {
+		t.Fatalf("NewTemplateSourceGetter: unexpected error: %v", err)
+	}
+
+	named := tg.GetNamedTemplates()
+	if _, ok := named["labels"]; !ok {
+		t.Errorf("GetNamedTemplates: missing %q, got %v", "labels", named)
+	}
+}
+
+func TestNewTemplateSourceGetterRemote(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+	}))
+	defer srv.Close()
+
+	in := &v1beta1.GoTemplate{
+		Source: v1beta1.RemoteSource,
+		Remote: strPtr(srv.URL + "/composite.yaml"),
+	}
+
+	tg, err := NewTemplateSourceGetter(nil, nil, in)
+	if err != nil {
+		t.Fatalf("NewTemplateSourceGetter: unexpected error: %v", err)
+	}
+
+	if tg.GetTemplates() == "" {
+		t.Error("GetTemplates: got empty root template")
+	}
+	if _, ok := tg.GetNamedTemplates()["composite"]; !ok {
+		t.Errorf("GetNamedTemplates: missing %q, got %v", "composite", tg.GetNamedTemplates())
+	}
+}
+
+func strPtr(s string) *string { return &s }